@@ -0,0 +1,55 @@
+// Command oapi-gen generates a Go ServerInterface and RegisterHandlers
+// function from an OpenAPI 3 document, validating path/query parameters
+// and JSON request bodies and generating typed JSON response writers; see
+// package openapi's doc comment for the exact scope.
+//
+//	oapi-gen -spec api/timeserver.yaml -package timeserver -out timeserver/timeserver_gen.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cleanCoder1999/learning-Go-ch13/openapi"
+)
+
+func main() {
+	spec := flag.String("spec", "", "path to the OpenAPI 3 YAML or JSON document")
+	pkg := flag.String("package", "", "package name for the generated file")
+	out := flag.String("out", "", "output path for the generated file")
+	flag.Parse()
+
+	if *spec == "" || *pkg == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*spec, *pkg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "oapi-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, pkg, outPath string) error {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return fmt.Errorf("reading spec: %w", err)
+	}
+
+	doc, err := openapi.Parse(data)
+	if err != nil {
+		return fmt.Errorf("parsing spec: %w", err)
+	}
+
+	code, err := openapi.Generate(doc, pkg)
+	if err != nil {
+		return fmt.Errorf("generating code: %w", err)
+	}
+
+	if err := os.WriteFile(outPath, code, 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", outPath, err)
+	}
+
+	return nil
+}
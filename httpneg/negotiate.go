@@ -0,0 +1,132 @@
+// Package httpneg implements server-driven content negotiation based on the
+// Accept request header, as described in RFC 7231 section 5.3.2.
+package httpneg
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// mediaRange is a single entry of an Accept header, e.g.
+// "application/json;q=0.9".
+type mediaRange struct {
+	typ, subtype string
+	q            float64
+}
+
+// specificity ranks a range so that exact matches outrank partial
+// wildcards, which in turn outrank the full wildcard "*/*".
+func (m mediaRange) specificity() int {
+	switch {
+	case m.typ != "*" && m.subtype != "*":
+		return 2
+	case m.typ != "*":
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (m mediaRange) matches(offer string) bool {
+	typ, subtype, ok := splitMediaType(offer)
+	if !ok {
+		return false
+	}
+	return (m.typ == "*" || m.typ == typ) && (m.subtype == "*" || m.subtype == subtype)
+}
+
+// Negotiate parses accept as a comma-separated list of media ranges with
+// optional q-values and returns the member of offers that is the best
+// match. offers are plain media types such as "application/json"; they are
+// not expected to carry parameters. If accept is empty, every offer is
+// acceptable and the first one is returned. If no offer satisfies any
+// range, Negotiate returns "".
+func Negotiate(accept string, offers []string) string {
+	if accept == "" && len(offers) > 0 {
+		return offers[0]
+	}
+
+	ranges := parseAccept(accept)
+	if len(ranges) == 0 {
+		return ""
+	}
+
+	bestOffer := ""
+	bestQ := 0.0
+	bestSpecificity := -1
+
+	for _, offer := range offers {
+		// the most specific matching range decides the offer's q-value, so
+		// "text/plain;q=0, */*" rules text/plain out.
+		q, spec := 0.0, -1
+		for _, r := range ranges {
+			if !r.matches(offer) {
+				continue
+			}
+			if s := r.specificity(); s > spec || (s == spec && r.q > q) {
+				q, spec = r.q, s
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+
+		if q > bestQ || (q == bestQ && spec > bestSpecificity) {
+			bestQ = q
+			bestSpecificity = spec
+			bestOffer = offer
+		}
+	}
+
+	return bestOffer
+}
+
+// parseAccept parses an Accept header value into its constituent media
+// ranges, ordered by no particular guarantee - callers should rely on
+// Negotiate rather than range order.
+func parseAccept(accept string) []mediaRange {
+	parts := strings.Split(accept, ",")
+	ranges := make([]mediaRange, 0, len(parts))
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		segments := strings.Split(part, ";")
+		typ, subtype, ok := splitMediaType(strings.TrimSpace(segments[0]))
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range segments[1:] {
+			param = strings.TrimSpace(param)
+			name, value, found := strings.Cut(param, "=")
+			if !found || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = v
+			}
+		}
+
+		ranges = append(ranges, mediaRange{typ: typ, subtype: subtype, q: q})
+	}
+
+	// stable order keeps equally-qualified ranges in header order, which
+	// only matters for callers inspecting the parsed ranges directly.
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	return ranges
+}
+
+func splitMediaType(s string) (typ, subtype string, ok bool) {
+	typ, subtype, found := strings.Cut(s, "/")
+	if !found || typ == "" || subtype == "" {
+		return "", "", false
+	}
+	return strings.ToLower(typ), strings.ToLower(subtype), true
+}
@@ -0,0 +1,99 @@
+package httpneg
+
+import "testing"
+
+func TestNegotiate(t *testing.T) {
+	offers := []string{"application/json", "text/plain", "text/html"}
+
+	tests := []struct {
+		name   string
+		accept string
+		offers []string
+		want   string
+	}{
+		{
+			name:   "empty accept picks the first offer",
+			accept: "",
+			offers: offers,
+			want:   "application/json",
+		},
+		{
+			name:   "exact match",
+			accept: "text/plain",
+			offers: offers,
+			want:   "text/plain",
+		},
+		{
+			name:   "higher q-value wins over header order",
+			accept: "application/json;q=0.5, text/plain;q=0.9",
+			offers: offers,
+			want:   "text/plain",
+		},
+		{
+			name:   "exact match outranks a partial wildcard at equal q",
+			accept: "text/*;q=0.8, text/html;q=0.8",
+			offers: offers,
+			want:   "text/html",
+		},
+		{
+			name:   "partial wildcard outranks full wildcard at equal q",
+			accept: "*/*;q=0.8, text/*;q=0.8",
+			offers: offers,
+			want:   "text/plain",
+		},
+		{
+			name:   "q=0 excludes an otherwise matching range",
+			accept: "text/plain;q=0, */*",
+			offers: []string{"text/plain", "application/json"},
+			want:   "application/json",
+		},
+		{
+			name:   "unparseable q defaults to 1",
+			accept: "text/plain;q=nonsense",
+			offers: offers,
+			want:   "text/plain",
+		},
+		{
+			name:   "no satisfying range returns empty",
+			accept: "application/xml",
+			offers: offers,
+			want:   "",
+		},
+		{
+			name:   "whitespace around ranges and params is tolerated",
+			accept: " text/plain ; q=0.9 , application/json ; q=0.1 ",
+			offers: offers,
+			want:   "text/plain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.accept, tt.offers); got != tt.want {
+				t.Errorf("Negotiate(%q, %v) = %q, want %q", tt.accept, tt.offers, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitMediaType(t *testing.T) {
+	tests := []struct {
+		in       string
+		typ, sub string
+		ok       bool
+	}{
+		{"application/json", "application", "json", true},
+		{"TEXT/HTML", "text", "html", true},
+		{"*/*", "*", "*", true},
+		{"noslash", "", "", false},
+		{"/json", "", "", false},
+		{"application/", "", "", false},
+	}
+
+	for _, tt := range tests {
+		typ, sub, ok := splitMediaType(tt.in)
+		if typ != tt.typ || sub != tt.sub || ok != tt.ok {
+			t.Errorf("splitMediaType(%q) = (%q, %q, %v), want (%q, %q, %v)", tt.in, typ, sub, ok, tt.typ, tt.sub, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package httpneg
+
+import "net/http"
+
+// Render negotiates the best representation for r out of offers and
+// invokes the matching entry of renderers, which must contain one function
+// per offer. It responds with 406 Not Acceptable if no offer satisfies the
+// request's Accept header.
+func Render(w http.ResponseWriter, r *http.Request, offers []string, renderers map[string]func(w http.ResponseWriter) error) error {
+	offer := Negotiate(r.Header.Get("Accept"), offers)
+	if offer == "" {
+		http.Error(w, "406 not acceptable", http.StatusNotAcceptable)
+		return nil
+	}
+
+	render, ok := renderers[offer]
+	if !ok {
+		http.Error(w, "406 not acceptable", http.StatusNotAcceptable)
+		return nil
+	}
+
+	w.Header().Set("Content-Type", offer)
+	return render(w)
+}
@@ -0,0 +1,118 @@
+package logging
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"time"
+)
+
+// AsyncWriter wraps an io.Writer so that writes are handed off to a
+// background goroutine and flushed to dst in batches, rather than
+// blocking the caller on every call. It is meant to sit underneath a
+// slog.Handler (e.g. slog.NewJSONHandler(asyncWriter, ...)) so that
+// request-path code never waits on log I/O.
+//
+// Writes are dropped if the internal buffer is full, trading durability
+// for a guarantee that logging never back-pressures request handling.
+type AsyncWriter struct {
+	dst           io.Writer
+	in            chan []byte
+	done          chan struct{}
+	batchSize     int
+	flushInterval time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewAsyncWriter starts a background goroutine that batches writes to dst,
+// flushing whenever batchSize records have accumulated or flushInterval
+// has elapsed, whichever comes first. Call Close to flush and stop it.
+func NewAsyncWriter(dst io.Writer, batchSize int, flushInterval time.Duration) *AsyncWriter {
+	w := &AsyncWriter{
+		dst:           dst,
+		in:            make(chan []byte, batchSize*2),
+		done:          make(chan struct{}),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+	}
+	go w.run()
+	return w
+}
+
+// Write copies p and queues it for the background flusher. It never
+// blocks: if the queue is full, the record is dropped. Writes that arrive
+// after Close has been called are dropped too, rather than racing the
+// channel close.
+func (w *AsyncWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return len(p), nil
+	}
+
+	buf := make([]byte, len(p))
+	copy(buf, p)
+
+	select {
+	case w.in <- buf:
+	default:
+	}
+
+	return len(p), nil
+}
+
+// Close flushes any buffered writes and stops the background goroutine. It
+// is safe to call concurrently with Write and is idempotent: only the
+// first call closes the input channel.
+func (w *AsyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.in)
+	w.mu.Unlock()
+
+	<-w.done
+	return nil
+}
+
+func (w *AsyncWriter) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	var batch bytes.Buffer
+	pending := 0
+
+	flush := func() {
+		if batch.Len() == 0 {
+			return
+		}
+		_, _ = w.dst.Write(batch.Bytes())
+		batch.Reset()
+		pending = 0
+	}
+
+	for {
+		select {
+		case buf, ok := <-w.in:
+			if !ok {
+				flush()
+				return
+			}
+			batch.Write(buf)
+			pending++
+			if pending >= w.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
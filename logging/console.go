@@ -0,0 +1,95 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+const (
+	colorReset = "\033[0m"
+	colorGray  = "\033[90m"
+	colorBlue  = "\033[34m"
+	colorGreen = "\033[32m"
+	colorRed   = "\033[31m"
+)
+
+// consoleHandler is a slog.Handler that writes short, colorized lines
+// meant for a developer's terminal rather than log aggregation.
+type consoleHandler struct {
+	w     io.Writer
+	mu    *sync.Mutex
+	level slog.Leveler
+	attrs []slog.Attr
+}
+
+// NewConsoleHandler returns a slog.Handler suitable for local development:
+// one colorized line per record, with attrs rendered as key=value pairs.
+// A nil level defaults to slog.LevelInfo.
+func NewConsoleHandler(w io.Writer, level slog.Leveler) slog.Handler {
+	if level == nil {
+		level = slog.LevelInfo
+	}
+	return &consoleHandler{w: w, mu: &sync.Mutex{}, level: level}
+}
+
+func (h *consoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *consoleHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+
+	b.WriteString(colorGray)
+	b.WriteString(r.Time.Format("15:04:05.000"))
+	b.WriteString(colorReset)
+	b.WriteByte(' ')
+	b.WriteString(levelColor(r.Level))
+	b.WriteString(r.Message)
+	b.WriteString(colorReset)
+
+	for _, a := range h.attrs {
+		writeAttr(&b, a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		writeAttr(&b, a)
+		return true
+	})
+	b.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	merged := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	merged = append(merged, h.attrs...)
+	merged = append(merged, attrs...)
+	return &consoleHandler{w: h.w, mu: h.mu, level: h.level, attrs: merged}
+}
+
+// WithGroup is a no-op: the console handler favours compact, flat output
+// over slog's grouping, which is of little use on a terminal.
+func (h *consoleHandler) WithGroup(_ string) slog.Handler {
+	return h
+}
+
+func writeAttr(b *strings.Builder, a slog.Attr) {
+	fmt.Fprintf(b, " %s%s=%v%s", colorGray, a.Key, a.Value, colorReset)
+}
+
+func levelColor(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return colorRed
+	case level >= slog.LevelWarn:
+		return colorBlue
+	default:
+		return colorGreen
+	}
+}
@@ -0,0 +1,61 @@
+// Package logging provides a small, allocation-conscious structured
+// logging abstraction on top of log/slog. A single Logger wraps one
+// process-wide slog.Handler configured at startup, rather than each call
+// site building its own; see the adapters in console.go and async.go for
+// handler implementations that can be swapped in.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Logger is implemented by this package's loggers and is the type
+// constructors throughout the repo accept, so callers can inject a test
+// double or a differently configured logger without importing log/slog
+// directly.
+type Logger interface {
+	Debug(msg string, fields ...slog.Attr)
+	Info(msg string, fields ...slog.Attr)
+	Warn(msg string, fields ...slog.Attr)
+	Error(msg string, fields ...slog.Attr)
+
+	// With returns a Logger that prepends fields to every subsequent call.
+	With(fields ...slog.Attr) Logger
+}
+
+// slogLogger adapts a slog.Handler to Logger. Unlike slog.Logger, it
+// builds the slog.Record directly from already-constructed attrs instead
+// of accepting "alternating key" arguments, so callers that already hold a
+// []slog.Attr (e.g. from AcquireAttrs) avoid slog's own conversion
+// allocation.
+type slogLogger struct {
+	handler slog.Handler
+}
+
+// New wraps handler as a Logger. handler is typically constructed once at
+// startup and shared by every Logger derived from it via With.
+func New(handler slog.Handler) Logger {
+	return &slogLogger{handler: handler}
+}
+
+func (l *slogLogger) Debug(msg string, fields ...slog.Attr) { l.log(slog.LevelDebug, msg, fields) }
+func (l *slogLogger) Info(msg string, fields ...slog.Attr)  { l.log(slog.LevelInfo, msg, fields) }
+func (l *slogLogger) Warn(msg string, fields ...slog.Attr)  { l.log(slog.LevelWarn, msg, fields) }
+func (l *slogLogger) Error(msg string, fields ...slog.Attr) { l.log(slog.LevelError, msg, fields) }
+
+func (l *slogLogger) With(fields ...slog.Attr) Logger {
+	return &slogLogger{handler: l.handler.WithAttrs(fields)}
+}
+
+func (l *slogLogger) log(level slog.Level, msg string, fields []slog.Attr) {
+	ctx := context.Background()
+	if !l.handler.Enabled(ctx, level) {
+		return
+	}
+
+	r := slog.NewRecord(time.Now(), level, msg, 0)
+	r.AddAttrs(fields...)
+	_ = l.handler.Handle(ctx, r)
+}
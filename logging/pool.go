@@ -0,0 +1,23 @@
+package logging
+
+import (
+	"log/slog"
+	"sync"
+)
+
+var attrPool = sync.Pool{
+	New: func() any { return make([]slog.Attr, 0, 8) },
+}
+
+// AcquireAttrs returns a zero-length []slog.Attr from a shared pool, so
+// hot paths like per-request logging can build their attr list without a
+// fresh allocation. Pair every call with ReleaseAttrs.
+func AcquireAttrs() []slog.Attr {
+	return attrPool.Get().([]slog.Attr)[:0]
+}
+
+// ReleaseAttrs returns attrs to the pool. Callers must not use attrs after
+// calling ReleaseAttrs.
+func ReleaseAttrs(attrs []slog.Attr) {
+	attrPool.Put(attrs[:0])
+}
@@ -1,154 +1,141 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
-	"strings"
-	"sync"
 	"time"
+
+	"github.com/cleanCoder1999/learning-Go-ch13/httpneg"
+	"github.com/cleanCoder1999/learning-Go-ch13/logging"
+	"github.com/cleanCoder1999/learning-Go-ch13/middleware"
+	"github.com/cleanCoder1999/learning-Go-ch13/server"
+	"github.com/cleanCoder1999/learning-Go-ch13/timeserver"
 )
 
+// timeOffers are the representations the time endpoint can produce, most
+// preferred first.
+var timeOffers = []string{"application/json", "text/plain", "text/html"}
+
+// timeServer implements timeserver.ServerInterface.
+type timeServer struct {
+	logger logging.Logger
+}
+
+func newTimeServer(logger logging.Logger) timeServer {
+	return timeServer{logger: logger}
+}
+
+func (s timeServer) GetTime(w http.ResponseWriter, r *http.Request) {
+	now := time.Now().UTC()
+
+	err := httpneg.Render(w, r, timeOffers, map[string]func(w http.ResponseWriter) error{
+		"application/json": func(w http.ResponseWriter) error {
+			_, err := w.Write([]byte(buildJson(now)))
+			return err
+		},
+		"text/plain": func(w http.ResponseWriter) error {
+			_, err := w.Write([]byte(now.Format(time.RFC3339)))
+			return err
+		},
+		"text/html": func(w http.ResponseWriter) error {
+			_, err := fmt.Fprintf(w, "<html><body><p>%s</p></body></html>", now.Format(time.RFC3339))
+			return err
+		},
+	})
+	if err != nil {
+		s.logger.Error(err.Error())
+	}
+}
+
+func (s timeServer) GetHello(w http.ResponseWriter, r *http.Request, name string) {
+	n, err := w.Write([]byte("Hello " + name))
+	if err != nil {
+		s.logger.Error(err.Error())
+		return
+	}
+
+	s.logger.Debug("wrote response", slog.Int("bytes", n))
+}
+
 func main() {
 
-	var wg sync.WaitGroup
-	wg.Add(4)
+	// logger wraps a single, process-wide handler; see package logging for
+	// the console and async-writer adapters this can be swapped for.
+	logger := logging.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	// shared middleware chain: request logging, panic recovery, request IDs.
+	// see package middleware for an alice-style Chain combinator modeled on
+	// chi's built-in middleware set.
+	chain := middleware.New(
+		middleware.RequestLogger(middleware.NewSlogFormatter(logger)),
+		middleware.NewRecoverer(logger),
+		middleware.RequestID,
+	)
+
+	// group supervises all three example servers: it starts them together,
+	// watches for SIGINT/SIGTERM, and drains in-flight requests on the way
+	// down. See package server for the implementation.
+	group := server.NewGroup(15 * time.Second)
+
 	// ### - exercise 1.1: write a small web server that returns the current time in RFC 3339 when a GET request is sent
 	{
 		// simple http server
-		s := http.Server{
+		s := &http.Server{
 			Addr:         ":8080",
 			ReadTimeout:  30 * time.Second,
 			WriteTimeout: 90 * time.Second,
 			IdleTimeout:  120 * time.Second,
-			Handler:      GetHandler{},
+			Handler:      chain.Then(NewGetHandler(logger)),
 		}
-
-		go func() {
-			err := s.ListenAndServe()
-			wg.Done()
-			if err != nil {
-				// ErrServerClosed is a Sentinel error indicating that the server shut down which is not an error per-se
-				if !errors.Is(err, http.ErrServerClosed) {
-					panic(err)
-				}
-			}
-		}()
+		group.Add(s)
 	}
 
 	// ### - exercise 1.2: (ServeMux) write a small web server that returns the current time in RFC 3339 when a GET request is sent
+	//
+	// "/" and "/hello/{name}" are now defined by api/timeserver.yaml; see
+	// package timeserver for the generated ServerInterface and
+	// RegisterHandlers this block wires up.
 	{
 		mux := http.NewServeMux()
+		timeserver.RegisterHandlers(mux, newTimeServer(logger))
+		mux.HandleFunc("/livez", group.Livez)
+		mux.HandleFunc("/readyz", group.Readyz)
 
-		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-
-			accept := r.Header.Get("Accept")
-			fmt.Println(accept)
-
-			now := time.Now().UTC()
-			response := now.Format(time.RFC3339)
-
-			if strings.ToUpper(accept) == "JSON" {
-				response = buildJson(now)
-			}
-
-			w.WriteHeader(http.StatusOK)
-			n, err := w.Write([]byte(response))
-			if err != nil {
-				slog.Error(err.Error())
-				return
-			}
-
-			fmt.Printf("%d bytes written\n", n)
-		})
-
-		// mux allows for path variables
-		mux.HandleFunc("/hello/{name}", func(w http.ResponseWriter, r *http.Request) {
-			name := r.PathValue("name")
-
-			n, err := w.Write([]byte("Hello " + name))
-			if err != nil {
-				slog.Error(err.Error())
-				return
-			}
-
-			fmt.Printf("%d bytes written\n", n)
-		})
-
-		s := http.Server{
+		s := &http.Server{
 			Addr:         ":8081",
 			ReadTimeout:  30 * time.Second,
 			WriteTimeout: 90 * time.Second,
 			IdleTimeout:  120 * time.Second,
-			Handler:      mux, // uses a mux as request handler
+			Handler:      chain.Then(mux), // uses a mux as request handler
 		}
-
-		go func() {
-			err := s.ListenAndServe()
-			wg.Done()
-			if err != nil {
-				// ErrServerClosed is a Sentinel error indicating that the server shut down which is not an error per-se
-				if !errors.Is(err, http.ErrServerClosed) {
-					panic(err)
-				}
-			}
-		}()
+		group.Add(s)
 	}
 
 	// ### - exercise 2: write a small middleware component that uses JSON structured logging to log the IP address of each ingress request
 	{
-		// this can be done by using ...
-		// (1) Go's standard lib support
-		// (2) alice (3rd party lib)
-		// (3) gorilla mux (3rd party lib)
-		// (4) chi (3rd party lib)
-		// (5) Gin (web framework)
-		// (4) Echo (web framework)
-		//
-		// for the exercise I use (1)
+		// now composed declaratively via the shared chain, see package
+		// middleware for the RequestLogger implementation
 		mux := http.NewServeMux()
+		mux.Handle("/log", newLogHandler(logger))
 
-		mux.Handle("/log",
-
-			// chains middleware function IpAddressLogger
-			IpAddressLogger(
-				http.HandlerFunc(
-					func(w http.ResponseWriter, r *http.Request) {
-						n, err := w.Write([]byte("logged IP"))
-						if err != nil {
-							slog.Error(err.Error())
-						}
-
-						fmt.Printf("%d bytes written\n", n)
-					},
-				),
-			),
-		)
-
-		s := http.Server{
+		s := &http.Server{
 			Addr:         ":8083",
 			ReadTimeout:  30 * time.Second,
 			WriteTimeout: 90 * time.Second,
 			IdleTimeout:  120 * time.Second,
-			Handler:      mux,
+			Handler:      chain.Then(mux),
 		}
-
-		go func() {
-			err := s.ListenAndServe()
-			wg.Done()
-			if err != nil {
-				// ErrServerClosed is a Sentinel error indicating that the server shut down which is not an error per-se
-				if !errors.Is(err, http.ErrServerClosed) {
-					panic(err)
-				}
-			}
-		}()
+		group.Add(s)
 	}
 
-	wg.Wait()
+	if err := group.Run(context.Background()); err != nil {
+		slog.Error(err.Error())
+		os.Exit(1)
+	}
 }
 
 func buildJson(now time.Time) string {
@@ -174,32 +161,45 @@ func buildJson(now time.Time) string {
 	return string(out)
 }
 
-func IpAddressLogger(h http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-
-		// create a structured logging instance
-		options := &slog.HandlerOptions{Level: slog.LevelInfo}
-		handler := slog.NewJSONHandler(os.Stdout, options)
-		mySlog := slog.New(handler)
-
-		// LogAttrs() provides higher performance than using "alternating keys"
-		mySlog.Info("slower logging with alternating keys", "ip:", r.RemoteAddr)
-		mySlog.LogAttrs(r.Context(), slog.LevelInfo, "faster logging with LogAttrs()", slog.String("ip:", r.RemoteAddr))
-
-		h.ServeHTTP(w, r)
-	})
+// GetHandler serves the current time in RFC 3339 format.
+type GetHandler struct {
+	logger logging.Logger
 }
 
-type GetHandler struct{}
+// NewGetHandler returns a GetHandler that reports write failures to
+// logger.
+func NewGetHandler(logger logging.Logger) GetHandler {
+	return GetHandler{logger: logger}
+}
 
 func (gh GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusOK)
 	n, err := w.Write([]byte(time.Now().Format(time.RFC3339)))
 	if err != nil {
-		slog.Error(err.Error())
+		gh.logger.Error(err.Error())
+		return
+	}
+
+	gh.logger.Debug("wrote response", slog.Int("bytes", n))
+}
+
+// logHandler serves /log, exercising the request-scoped logging set up by
+// the shared middleware chain.
+type logHandler struct {
+	logger logging.Logger
+}
+
+func newLogHandler(logger logging.Logger) logHandler {
+	return logHandler{logger: logger}
+}
+
+func (h logHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n, err := w.Write([]byte("logged IP"))
+	if err != nil {
+		h.logger.Error(err.Error())
 		return
 	}
 
-	fmt.Printf("%d bytes written\n", n)
+	h.logger.Debug("wrote response", slog.Int("bytes", n))
 }
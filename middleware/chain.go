@@ -0,0 +1,74 @@
+// Package middleware provides composable http.Handler middleware, modeled
+// after justinas/alice's Chain and chi's built-in middleware set.
+package middleware
+
+import "net/http"
+
+// Middleware wraps an http.Handler with additional behaviour.
+type Middleware func(http.Handler) http.Handler
+
+// Chain is an immutable list of middleware that can be extended and applied
+// to a handler. The zero value is an empty chain.
+type Chain struct {
+	middlewares []Middleware
+}
+
+// New creates a Chain of middleware, applied in the order they are passed
+// in. The first middleware is the outermost wrapper, i.e. it sees the
+// request first and the response last.
+func New(middlewares ...Middleware) Chain {
+	return Chain{middlewares: append([]Middleware(nil), middlewares...)}
+}
+
+// Then chains the middleware and returns the final http.Handler.
+//
+//	middleware.New(m1, m2, m3).Then(handler)
+//
+// is equivalent to:
+//
+//	m1(m2(m3(handler)))
+//
+// A nil handler defaults to http.DefaultServeMux.
+func (c Chain) Then(h http.Handler) http.Handler {
+	if h == nil {
+		h = http.DefaultServeMux
+	}
+
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		h = c.middlewares[i](h)
+	}
+
+	return h
+}
+
+// ThenFunc works identically to Then, but takes a HandlerFunc instead of a
+// Handler.
+func (c Chain) ThenFunc(fn http.HandlerFunc) http.Handler {
+	if fn == nil {
+		return c.Then(nil)
+	}
+	return c.Then(fn)
+}
+
+// Append extends a chain by adding the specified middlewares as the last
+// ones in the request flow.
+//
+//	c := middleware.New(m1, m2)
+//	c.Append(m3, m4)
+//	// results in m1, m2, m3, m4
+func (c Chain) Append(middlewares ...Middleware) Chain {
+	newChain := make([]Middleware, 0, len(c.middlewares)+len(middlewares))
+	newChain = append(newChain, c.middlewares...)
+	newChain = append(newChain, middlewares...)
+	return Chain{middlewares: newChain}
+}
+
+// Extend extends a chain by adding the specified chain as the last one in
+// the request flow.
+//
+//	c := middleware.New(m1, m2)
+//	c.Extend(middleware.New(m3, m4))
+//	// results in m1, m2, m3, m4
+func (c Chain) Extend(chain Chain) Chain {
+	return c.Append(chain.middlewares...)
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// tagMiddleware returns a Middleware that appends tag to a shared trace
+// slice before calling the next handler, so tests can observe call order.
+func tagMiddleware(trace *[]string, tag string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*trace = append(*trace, tag)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestChainThenOrdersMiddlewareOutermostFirst(t *testing.T) {
+	var trace []string
+
+	handler := New(
+		tagMiddleware(&trace, "m1"),
+		tagMiddleware(&trace, "m2"),
+		tagMiddleware(&trace, "m3"),
+	).ThenFunc(func(w http.ResponseWriter, r *http.Request) {
+		trace = append(trace, "handler")
+	})
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	want := []string{"m1", "m2", "m3", "handler"}
+	if len(trace) != len(want) {
+		t.Fatalf("trace = %v, want %v", trace, want)
+	}
+	for i := range want {
+		if trace[i] != want[i] {
+			t.Fatalf("trace = %v, want %v", trace, want)
+		}
+	}
+}
+
+func TestChainThenNilHandlerDefaultsToDefaultServeMux(t *testing.T) {
+	handler := New().Then(nil)
+	if handler != http.DefaultServeMux {
+		t.Fatalf("Then(nil) = %v, want http.DefaultServeMux", handler)
+	}
+}
+
+func TestChainAppendAddsToTheEnd(t *testing.T) {
+	var trace []string
+
+	base := New(tagMiddleware(&trace, "m1"), tagMiddleware(&trace, "m2"))
+	extended := base.Append(tagMiddleware(&trace, "m3"))
+
+	extended.ThenFunc(func(w http.ResponseWriter, r *http.Request) {}).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	want := []string{"m1", "m2", "m3"}
+	if len(trace) != len(want) {
+		t.Fatalf("trace = %v, want %v", trace, want)
+	}
+	for i := range want {
+		if trace[i] != want[i] {
+			t.Fatalf("trace = %v, want %v", trace, want)
+		}
+	}
+
+	// base itself must be unmodified.
+	trace = nil
+	base.ThenFunc(func(w http.ResponseWriter, r *http.Request) {}).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if len(trace) != 2 {
+		t.Fatalf("base chain was mutated by Append: trace = %v", trace)
+	}
+}
+
+func TestChainExtend(t *testing.T) {
+	var trace []string
+
+	c := New(tagMiddleware(&trace, "m1"), tagMiddleware(&trace, "m2"))
+	extended := c.Extend(New(tagMiddleware(&trace, "m3"), tagMiddleware(&trace, "m4")))
+
+	extended.ThenFunc(func(w http.ResponseWriter, r *http.Request) {}).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	want := []string{"m1", "m2", "m3", "m4"}
+	if len(trace) != len(want) {
+		t.Fatalf("trace = %v, want %v", trace, want)
+	}
+	for i := range want {
+		if trace[i] != want[i] {
+			t.Fatalf("trace = %v, want %v", trace, want)
+		}
+	}
+}
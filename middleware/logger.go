@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cleanCoder1999/learning-Go-ch13/logging"
+)
+
+// LogEntry is returned by a LogFormatter for every request and is notified
+// once the request has completed.
+type LogEntry interface {
+	// Write is called when the wrapped handler has finished serving the
+	// request. extra may be used to attach information discovered while
+	// serving the request, e.g. a recovered panic.
+	Write(status, bytes int, header http.Header, elapsed time.Duration, extra any)
+}
+
+// LogFormatter builds a LogEntry for an incoming request. Implementations
+// decide what gets logged and in which format.
+type LogFormatter interface {
+	NewLogEntry(r *http.Request) LogEntry
+}
+
+// RequestLogger returns a middleware that logs each request using the
+// entries produced by f.
+func RequestLogger(f LogFormatter) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			entry := f.NewLogEntry(r)
+			ww := wrapWriter(w)
+
+			start := time.Now()
+			next.ServeHTTP(ww, r)
+			entry.Write(ww.Status(), ww.BytesWritten(), w.Header(), time.Since(start), nil)
+		})
+	}
+}
+
+// slogFormatter is the default LogFormatter. It emits one structured
+// record per request via an injected logging.Logger, rather than building
+// its own handler.
+type slogFormatter struct {
+	logger logging.Logger
+}
+
+// NewSlogFormatter returns a LogFormatter that writes one structured
+// record per request via logger.
+func NewSlogFormatter(logger logging.Logger) LogFormatter {
+	return &slogFormatter{logger: logger}
+}
+
+func (f *slogFormatter) NewLogEntry(r *http.Request) LogEntry {
+	return &slogLogEntry{
+		logger:    f.logger,
+		method:    r.Method,
+		path:      r.URL.Path,
+		proto:     r.Proto,
+		remoteIP:  remoteIP(r),
+		userAgent: r.UserAgent(),
+	}
+}
+
+type slogLogEntry struct {
+	logger    logging.Logger
+	method    string
+	path      string
+	proto     string
+	remoteIP  string
+	userAgent string
+}
+
+func (e *slogLogEntry) Write(status, bytes int, _ http.Header, elapsed time.Duration, extra any) {
+	attrs := logging.AcquireAttrs()
+	defer logging.ReleaseAttrs(attrs)
+
+	attrs = append(attrs,
+		slog.String("method", e.method),
+		slog.String("path", e.path),
+		slog.String("proto", e.proto),
+		slog.String("remote_ip", e.remoteIP),
+		slog.String("user_agent", e.userAgent),
+		slog.Int("status", status),
+		slog.Int("bytes", bytes),
+		slog.Duration("latency", elapsed),
+	)
+	if extra != nil {
+		attrs = append(attrs, slog.Any("extra", extra))
+	}
+
+	e.logger.Info("request completed", attrs...)
+}
+
+// remoteIP returns the client's address, preferring the left-most entry of
+// X-Forwarded-For when present.
+func remoteIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		if i := strings.IndexByte(fwd, ','); i != -1 {
+			return strings.TrimSpace(fwd[:i])
+		}
+		return strings.TrimSpace(fwd)
+	}
+	return r.RemoteAddr
+}
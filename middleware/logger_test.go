@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cleanCoder1999/learning-Go-ch13/logging"
+)
+
+// fakeLogger records the Info and Error calls made against it (Debug and
+// Warn are discarded), so tests can assert on what RequestLogger/Recoverer
+// actually log without parsing slog output.
+type fakeLogger struct {
+	infos  []fakeLogCall
+	errors []fakeLogCall
+}
+
+type fakeLogCall struct {
+	msg   string
+	attrs []slog.Attr
+}
+
+func (f *fakeLogger) Debug(msg string, fields ...slog.Attr) {}
+func (f *fakeLogger) Info(msg string, fields ...slog.Attr) {
+	f.infos = append(f.infos, fakeLogCall{msg: msg, attrs: fields})
+}
+func (f *fakeLogger) Warn(msg string, fields ...slog.Attr) {}
+func (f *fakeLogger) Error(msg string, fields ...slog.Attr) {
+	f.errors = append(f.errors, fakeLogCall{msg: msg, attrs: fields})
+}
+func (f *fakeLogger) With(fields ...slog.Attr) logging.Logger { return f }
+
+func attrInt(t *testing.T, call fakeLogCall, key string) int64 {
+	t.Helper()
+	for _, a := range call.attrs {
+		if a.Key == key {
+			return a.Value.Int64()
+		}
+	}
+	t.Fatalf("attr %q not found in %v", key, call.attrs)
+	return 0
+}
+
+func TestRequestLoggerCapturesStatusAndBytes(t *testing.T) {
+	logger := &fakeLogger{}
+
+	handler := RequestLogger(NewSlogFormatter(logger))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte("hello"))
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/brew", nil))
+
+	if len(logger.infos) != 1 {
+		t.Fatalf("got %d Info calls, want 1", len(logger.infos))
+	}
+	call := logger.infos[0]
+	if call.msg != "request completed" {
+		t.Errorf("msg = %q, want %q", call.msg, "request completed")
+	}
+	if got := attrInt(t, call, "status"); got != http.StatusTeapot {
+		t.Errorf("status attr = %d, want %d", got, http.StatusTeapot)
+	}
+	if got := attrInt(t, call, "bytes"); got != int64(len("hello")) {
+		t.Errorf("bytes attr = %d, want %d", got, len("hello"))
+	}
+}
+
+func TestRequestLoggerDefaultsStatusTo200WhenHandlerNeverWrites(t *testing.T) {
+	logger := &fakeLogger{}
+
+	handler := RequestLogger(NewSlogFormatter(logger))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// never calls WriteHeader or Write
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := attrInt(t, logger.infos[0], "status"); got != http.StatusOK {
+		t.Errorf("status attr = %d, want %d", got, http.StatusOK)
+	}
+}
+
+func TestRemoteIPPrefersForwardedFor(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("X-Forwarded-For", "203.0.113.5, 10.0.0.1")
+
+	if got, want := remoteIP(r), "203.0.113.5"; got != want {
+		t.Errorf("remoteIP() = %q, want %q", got, want)
+	}
+}
+
+func TestRemoteIPFallsBackToRemoteAddr(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+
+	if got, want := remoteIP(r), "10.0.0.1:1234"; got != want {
+		t.Errorf("remoteIP() = %q, want %q", got, want)
+	}
+}
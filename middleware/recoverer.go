@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/cleanCoder1999/learning-Go-ch13/logging"
+)
+
+// NewRecoverer returns a middleware that recovers from panics in the
+// handler chain, logs the panic and stack trace via logger, and responds
+// with 500 Internal Server Error instead of crashing the process.
+func NewRecoverer(logger logging.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if rvr := recover(); rvr != nil {
+					logger.Error("panic recovered",
+						slog.Any("error", rvr),
+						slog.String("stack", string(debug.Stack())),
+					)
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
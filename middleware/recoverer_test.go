@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecovererRecoversPanicAnd500s(t *testing.T) {
+	logger := &fakeLogger{}
+
+	handler := NewRecoverer(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if len(logger.errors) != 1 {
+		t.Fatalf("got %d Error calls, want 1", len(logger.errors))
+	}
+	if logger.errors[0].msg != "panic recovered" {
+		t.Errorf("msg = %q, want %q", logger.errors[0].msg, "panic recovered")
+	}
+}
+
+func TestRecovererLeavesNonPanickingRequestsUntouched(t *testing.T) {
+	logger := &fakeLogger{}
+
+	handler := NewRecoverer(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if len(logger.errors) != 0 {
+		t.Errorf("got %d Error calls, want 0", len(logger.errors))
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import "net/http"
+
+// wrapResponseWriter wraps an http.ResponseWriter so that the status code
+// and number of bytes written can be observed after the handler returns.
+type wrapResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func wrapWriter(w http.ResponseWriter) *wrapResponseWriter {
+	return &wrapResponseWriter{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (w *wrapResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.status = status
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *wrapResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+func (w *wrapResponseWriter) Status() int {
+	return w.status
+}
+
+func (w *wrapResponseWriter) BytesWritten() int {
+	return w.bytes
+}
@@ -0,0 +1,496 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// httpMethods lists the methods Generate looks for on a PathItem, in the
+// order they are emitted.
+var httpMethods = []struct {
+	name string
+	get  func(PathItem) *Operation
+}{
+	{"GET", func(p PathItem) *Operation { return p.Get }},
+	{"POST", func(p PathItem) *Operation { return p.Post }},
+	{"PUT", func(p PathItem) *Operation { return p.Put }},
+	{"DELETE", func(p PathItem) *Operation { return p.Delete }},
+}
+
+// genOperation is the template-friendly view of a single operation built
+// from the document, resolved to a concrete method name and pattern.
+type genOperation struct {
+	Method      string
+	Path        string
+	Pattern     string // Go 1.22 ServeMux pattern, e.g. "GET /hello/{name}"
+	GoName      string // exported ServerInterface method name
+	PathParams  []genParam
+	QueryParams []genParam
+	ParamsType  string // name of the generated *Params struct, or ""
+	RequestBody *genBody
+	Responses   []genResponse
+}
+
+type genParam struct {
+	Name     string // wire name, e.g. "name"
+	GoName   string // exported Go field/identifier name, e.g. "Name"
+	Required bool
+	Enum     []string
+	Format   string
+	Min, Max *float64
+	IsNumber bool
+}
+
+// genBody is the template-friendly view of an operation's JSON request
+// body, built from its "application/json" schema.
+type genBody struct {
+	GoName   string // e.g. "CreateWidgetBody"
+	Required bool
+	Fields   []genField
+}
+
+// genResponse is the template-friendly view of one numeric-status,
+// "application/json" response, built from its schema.
+type genResponse struct {
+	Status     string // e.g. "200"
+	GoName     string // e.g. "CreateWidgetResponse200"
+	WriterFunc string // e.g. "WriteCreateWidgetResponse200"
+	Fields     []genField
+}
+
+// genField is one property of a request body or response schema.
+type genField struct {
+	Name     string // wire name, e.g. "email"
+	GoName   string // exported Go field name, e.g. "Email"
+	GoType   string // Go type used for the generated struct field
+	Type     string // JSON Schema type: "string", "integer", "number", "boolean"
+	Required bool
+	Enum     []string
+	Format   string
+	Min, Max *float64
+}
+
+// Generate renders Go source defining a ServerInterface and
+// RegisterHandlers function for doc's paths, in package pkgName.
+//
+// Path and query parameters are validated (required/enum/range/format)
+// and passed to ServerInterface methods as strings: path parameters as
+// string arguments, query parameters as *string fields of a generated
+// <Op>Params struct argument. A JSON request body is
+// decoded, validated the same way field-by-field, and passed as a typed
+// struct argument; a JSON response with a schema gets a generated struct
+// and a Write<Op>Response<Status> helper that marshals and writes it.
+// Only the "application/json" content type is supported for bodies and
+// responses - other content types, and "default" responses (which have
+// no numeric status to write), are modeled in Document but not
+// generated for.
+func Generate(doc *Document, pkgName string) ([]byte, error) {
+	ops, err := collectOperations(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var needsOpenapi, needsStrconv, needsJSON bool
+	for _, op := range ops {
+		for _, p := range op.PathParams {
+			if len(p.Enum) > 0 || p.Format != "" || p.IsNumber {
+				needsOpenapi = true
+			}
+			if p.IsNumber {
+				needsStrconv = true
+			}
+		}
+		for _, p := range op.QueryParams {
+			if len(p.Enum) > 0 || p.Format != "" || p.Required || p.IsNumber {
+				needsOpenapi = true
+			}
+			if p.IsNumber {
+				needsStrconv = true
+			}
+		}
+		if op.RequestBody != nil {
+			needsJSON = true
+			for _, f := range op.RequestBody.Fields {
+				if f.Required || len(f.Enum) > 0 || f.Format != "" || f.Min != nil || f.Max != nil {
+					needsOpenapi = true
+				}
+			}
+		}
+		if len(op.Responses) > 0 {
+			needsJSON = true
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := codegenTemplate.Execute(&buf, struct {
+		Package      string
+		Ops          []genOperation
+		NeedsOpenapi bool
+		NeedsStrconv bool
+		NeedsJSON    bool
+	}{Package: pkgName, Ops: ops, NeedsOpenapi: needsOpenapi, NeedsStrconv: needsStrconv, NeedsJSON: needsJSON}); err != nil {
+		return nil, fmt.Errorf("openapi: rendering template: %w", err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("openapi: formatting generated source: %w (source follows)\n%s", err, buf.String())
+	}
+
+	return formatted, nil
+}
+
+func collectOperations(doc *Document) ([]genOperation, error) {
+	paths := make([]string, 0, len(doc.Paths))
+	for p := range doc.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var ops []genOperation
+	for _, path := range paths {
+		item := doc.Paths[path]
+		for _, m := range httpMethods {
+			op := m.get(item)
+			if op == nil {
+				continue
+			}
+
+			if op.OperationID == "" {
+				return nil, fmt.Errorf("openapi: %s %s: operationId is required", m.name, path)
+			}
+
+			g := genOperation{
+				Method:  m.name,
+				Path:    path,
+				Pattern: m.name + " " + path,
+				GoName:  strings.ToUpper(op.OperationID[:1]) + op.OperationID[1:],
+			}
+
+			for _, p := range op.Parameters {
+				gp := genParam{
+					Name:     p.Name,
+					GoName:   strings.ToUpper(p.Name[:1]) + p.Name[1:],
+					Required: p.Required,
+					Enum:     p.Schema.Enum,
+					Format:   p.Schema.Format,
+					Min:      p.Schema.Minimum,
+					Max:      p.Schema.Maximum,
+					IsNumber: p.Schema.Type == "integer" || p.Schema.Type == "number",
+				}
+
+				switch p.In {
+				case "path":
+					g.PathParams = append(g.PathParams, gp)
+				case "query":
+					g.QueryParams = append(g.QueryParams, gp)
+				default:
+					return nil, fmt.Errorf("openapi: %s %s: unsupported parameter location %q for %q", m.name, path, p.In, p.Name)
+				}
+			}
+
+			if len(g.QueryParams) > 0 {
+				g.ParamsType = g.GoName + "Params"
+			}
+
+			if op.RequestBody != nil {
+				if mt, ok := op.RequestBody.Content["application/json"]; ok {
+					if fields := buildSchemaFields(mt.Schema); len(fields) > 0 {
+						g.RequestBody = &genBody{
+							GoName:   g.GoName + "Body",
+							Required: op.RequestBody.Required,
+							Fields:   fields,
+						}
+					}
+				}
+			}
+
+			statuses := make([]string, 0, len(op.Responses))
+			for status := range op.Responses {
+				statuses = append(statuses, status)
+			}
+			sort.Strings(statuses)
+			for _, status := range statuses {
+				// "default" (and any other key that is not an integer, such
+				// as "2XX") has no numeric status to pass to WriteHeader;
+				// skip rather than guess.
+				if _, err := strconv.Atoi(status); err != nil {
+					continue
+				}
+				mt, ok := op.Responses[status].Content["application/json"]
+				if !ok {
+					continue
+				}
+				fields := buildSchemaFields(mt.Schema)
+				if len(fields) == 0 {
+					continue
+				}
+				responseGoName := g.GoName + "Response" + status
+				g.Responses = append(g.Responses, genResponse{
+					Status:     status,
+					GoName:     responseGoName,
+					WriterFunc: "Write" + responseGoName,
+					Fields:     fields,
+				})
+			}
+
+			ops = append(ops, g)
+		}
+	}
+
+	return ops, nil
+}
+
+// buildSchemaFields converts an object schema's properties into the
+// fields of a generated struct, sorted by wire name for deterministic
+// output. Schemas with no properties (including non-object schemas, which
+// this generator does not model as structs) yield no fields.
+func buildSchemaFields(schema Schema) []genField {
+	if len(schema.Properties) == 0 {
+		return nil
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]genField, 0, len(names))
+	for _, name := range names {
+		prop := schema.Properties[name]
+		fields = append(fields, genField{
+			Name:     name,
+			GoName:   strings.ToUpper(name[:1]) + name[1:],
+			GoType:   schemaGoType(prop),
+			Type:     prop.Type,
+			Required: required[name],
+			Enum:     prop.Enum,
+			Format:   prop.Format,
+			Min:      prop.Minimum,
+			Max:      prop.Maximum,
+		})
+	}
+	return fields
+}
+
+// schemaGoType maps a JSON Schema scalar type to the Go type used for the
+// corresponding generated struct field. Object and array properties (and
+// anything else unrecognized) fall back to "any", since this generator
+// only validates the scalar constraints (enum/range/format) OpenAPI
+// documents commonly put on flat request/response bodies.
+func schemaGoType(s Schema) string {
+	switch s.Type {
+	case "integer", "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "string":
+		return "string"
+	default:
+		return "any"
+	}
+}
+
+// jsonTag renders a backtick-quoted json struct tag for name. It exists
+// as a template function, rather than being written inline in the
+// template text below, because that text is itself a raw string literal
+// and so cannot contain a literal backtick.
+var codegenFuncs = template.FuncMap{
+	"jsonTag": func(name string) string {
+		return "`json:\"" + name + "\"`"
+	},
+}
+
+var codegenTemplate = template.Must(template.New("openapi").Funcs(codegenFuncs).Parse(`// Code generated by cmd/oapi-gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"net/http"
+{{- if .NeedsStrconv}}
+	"strconv"
+{{- end}}
+{{- if .NeedsJSON}}
+	"encoding/json"
+{{- end}}
+{{- if .NeedsOpenapi}}
+
+	"github.com/cleanCoder1999/learning-Go-ch13/openapi"
+{{- end}}
+)
+
+// ServerInterface is implemented by handlers serving this API's operations.
+type ServerInterface interface {
+{{- range .Ops}}
+	{{.GoName}}(w http.ResponseWriter, r *http.Request{{range .PathParams}}, {{.Name}} string{{end}}{{if .ParamsType}}, params {{.ParamsType}}{{end}}{{if .RequestBody}}, body {{.RequestBody.GoName}}{{end}})
+{{- end}}
+}
+
+{{range .Ops}}
+{{- $op := .}}
+{{- if .ParamsType}}
+// {{.ParamsType}} holds the query parameters accepted by {{.GoName}}.
+type {{.ParamsType}} struct {
+{{- range .QueryParams}}
+	{{.GoName}} *string
+{{- end}}
+}
+{{end}}
+{{- if .RequestBody}}
+// {{.RequestBody.GoName}} holds the request body accepted by {{.GoName}}.
+type {{.RequestBody.GoName}} struct {
+{{- range .RequestBody.Fields}}
+	{{.GoName}} {{.GoType}} {{jsonTag .Name}}
+{{- end}}
+}
+{{end}}
+{{- range .Responses}}
+// {{.GoName}} is the typed {{.Status}} response body for {{$op.GoName}}.
+type {{.GoName}} struct {
+{{- range .Fields}}
+	{{.GoName}} {{.GoType}} {{jsonTag .Name}}
+{{- end}}
+}
+
+// {{.WriterFunc}} marshals body as JSON and writes it as {{$op.GoName}}'s
+// {{.Status}} response.
+func {{.WriterFunc}}(w http.ResponseWriter, body {{.GoName}}) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader({{.Status}})
+	return json.NewEncoder(w).Encode(body)
+}
+{{end}}
+{{- end}}
+
+// RegisterHandlers registers si's operations onto mux using Go 1.22
+// ServeMux patterns.
+func RegisterHandlers(mux *http.ServeMux, si ServerInterface) {
+{{- range .Ops}}
+	mux.HandleFunc({{printf "%q" .Pattern}}, func(w http.ResponseWriter, r *http.Request) {
+	{{- range .PathParams}}
+		{{.Name}} := r.PathValue({{printf "%q" .Name}})
+		{{- if .Enum}}
+		if err := openapi.ValidateEnum({{printf "%q" .Name}}, {{.Name}}, {{printf "%#v" .Enum}}); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		{{- end}}
+		{{- if .Format}}
+		if err := openapi.ValidateFormat({{printf "%q" .Name}}, {{.Name}}, {{printf "%q" .Format}}); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		{{- end}}
+		{{- if .IsNumber}}
+		if n, err := strconv.ParseFloat({{.Name}}, 64); err != nil {
+			http.Error(w, {{printf "%q" .Name}}+": not a number", http.StatusBadRequest)
+			return
+		} else if err := openapi.ValidateRange({{printf "%q" .Name}}, n, {{if .Min}}floatPtr({{.Min}}){{else}}nil{{end}}, {{if .Max}}floatPtr({{.Max}}){{else}}nil{{end}}); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		{{- end}}
+	{{- end}}
+	{{- if .ParamsType}}
+		var params {{.ParamsType}}
+		{{- range .QueryParams}}
+		if v := r.URL.Query().Get({{printf "%q" .Name}}); v != "" {
+			{{- if .Enum}}
+			if err := openapi.ValidateEnum({{printf "%q" .Name}}, v, {{printf "%#v" .Enum}}); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			{{- end}}
+			{{- if .Format}}
+			if err := openapi.ValidateFormat({{printf "%q" .Name}}, v, {{printf "%q" .Format}}); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			{{- end}}
+			{{- if .IsNumber}}
+			if n, err := strconv.ParseFloat(v, 64); err != nil {
+				http.Error(w, {{printf "%q" .Name}}+": not a number", http.StatusBadRequest)
+				return
+			} else if err := openapi.ValidateRange({{printf "%q" .Name}}, n, {{if .Min}}floatPtr({{.Min}}){{else}}nil{{end}}, {{if .Max}}floatPtr({{.Max}}){{else}}nil{{end}}); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			{{- end}}
+			params.{{.GoName}} = &v
+		}
+		{{- if .Required}} else {
+			err := openapi.ValidateRequired({{printf "%q" .Name}}, false)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		{{- end}}
+		{{- end}}
+	{{- end}}
+	{{- if .RequestBody}}
+		var rawBody map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&rawBody); err != nil {
+			http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		{{- range .RequestBody.Fields}}
+		{{- if .Required}}
+		if _, ok := rawBody[{{printf "%q" .Name}}]; !ok {
+			err := openapi.ValidateRequired({{printf "%q" .Name}}, false)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		{{- end}}
+		{{- if and (eq .Type "string") (or .Enum .Format)}}
+		if v, ok := rawBody[{{printf "%q" .Name}}].(string); ok {
+			{{- if .Enum}}
+			if err := openapi.ValidateEnum({{printf "%q" .Name}}, v, {{printf "%#v" .Enum}}); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			{{- end}}
+			{{- if .Format}}
+			if err := openapi.ValidateFormat({{printf "%q" .Name}}, v, {{printf "%q" .Format}}); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			{{- end}}
+		}
+		{{- end}}
+		{{- if or (eq .Type "integer") (eq .Type "number")}}
+		if n, ok := rawBody[{{printf "%q" .Name}}].(float64); ok {
+			if err := openapi.ValidateRange({{printf "%q" .Name}}, n, {{if .Min}}floatPtr({{.Min}}){{else}}nil{{end}}, {{if .Max}}floatPtr({{.Max}}){{else}}nil{{end}}); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		{{- end}}
+		{{- end}}
+		bodyJSON, err := json.Marshal(rawBody)
+		if err != nil {
+			http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		var body {{.RequestBody.GoName}}
+		if err := json.Unmarshal(bodyJSON, &body); err != nil {
+			http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	{{- end}}
+		si.{{.GoName}}(w, r{{range .PathParams}}, {{.Name}}{{end}}{{if .ParamsType}}, params{{end}}{{if .RequestBody}}, body{{end}})
+	})
+{{- end}}
+}
+
+func floatPtr(f float64) *float64 { return &f }
+`))
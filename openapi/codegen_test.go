@@ -0,0 +1,171 @@
+package openapi
+
+import (
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestGenerateTimeserverSpecUnchanged pins Generate's output for the real
+// spec this repo ships, so a change to the generator that alters behavior
+// for the spec in use is caught here rather than only by manual
+// regeneration.
+func TestGenerateTimeserverSpecUnchanged(t *testing.T) {
+	spec, err := os.ReadFile("../api/timeserver.yaml")
+	if err != nil {
+		t.Fatalf("reading spec: %v", err)
+	}
+	doc, err := Parse(spec)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	got, err := Generate(doc, "timeserver")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want, err := os.ReadFile("../timeserver/timeserver_gen.go")
+	if err != nil {
+		t.Fatalf("reading checked-in output: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Generate() output does not match ../timeserver/timeserver_gen.go; re-run cmd/oapi-gen and commit the result")
+	}
+}
+
+// genSrc runs Generate and fails the test if the result isn't valid,
+// gofmt-formatted Go source, returning the source so callers can look for
+// specific generated snippets.
+func genSrc(t *testing.T, doc *Document) string {
+	t.Helper()
+
+	code, err := Generate(doc, "gen")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	src := string(code)
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "gen.go", src, 0); err != nil {
+		t.Fatalf("Generate() output does not parse as Go: %v\n%s", err, src)
+	}
+	if formatted, err := format.Source(code); err != nil || string(formatted) != src {
+		t.Errorf("Generate() output is not gofmt-formatted")
+	}
+	return src
+}
+
+func TestGeneratePathParamConstraintsAreValidated(t *testing.T) {
+	doc := &Document{
+		Paths: map[string]PathItem{
+			"/colors/{name}": {
+				Get: &Operation{
+					OperationID: "getColor",
+					Parameters: []Parameter{
+						{
+							Name: "name", In: "path", Required: true,
+							Schema: Schema{Type: "string", Enum: []string{"red", "blue"}},
+						},
+					},
+					Responses: map[string]Response{"200": {}},
+				},
+			},
+		},
+	}
+
+	src := genSrc(t, doc)
+
+	if !strings.Contains(src, `name := r.PathValue("name")`) {
+		t.Errorf("expected path param to be bound via r.PathValue, got:\n%s", src)
+	}
+	if !strings.Contains(src, `openapi.ValidateEnum("name", name, []string{"red", "blue"})`) {
+		t.Errorf("expected path param enum validation, got:\n%s", src)
+	}
+}
+
+func TestGenerateRequiredQueryParamUsesValidateRequired(t *testing.T) {
+	doc := &Document{
+		Paths: map[string]PathItem{
+			"/search": {
+				Get: &Operation{
+					OperationID: "search",
+					Parameters: []Parameter{
+						{Name: "q", In: "query", Required: true, Schema: Schema{Type: "string"}},
+					},
+					Responses: map[string]Response{"200": {}},
+				},
+			},
+		},
+	}
+
+	src := genSrc(t, doc)
+
+	if !strings.Contains(src, `openapi.ValidateRequired("q", false)`) {
+		t.Errorf("expected missing required query param to call openapi.ValidateRequired, got:\n%s", src)
+	}
+}
+
+func TestGenerateRequestBodyValidationAndTypedResponse(t *testing.T) {
+	min, max := 0.0, 150.0
+	doc := &Document{
+		Paths: map[string]PathItem{
+			"/widgets": {
+				Post: &Operation{
+					OperationID: "createWidget",
+					RequestBody: &RequestBody{
+						Required: true,
+						Content: map[string]MediaType{
+							"application/json": {Schema: Schema{
+								Type:     "object",
+								Required: []string{"name"},
+								Properties: map[string]Schema{
+									"name": {Type: "string"},
+									"kind": {Type: "string", Enum: []string{"a", "b"}},
+									"age":  {Type: "integer", Minimum: &min, Maximum: &max},
+								},
+							}},
+						},
+					},
+					Responses: map[string]Response{
+						"201": {
+							Content: map[string]MediaType{
+								"application/json": {Schema: Schema{
+									Type:       "object",
+									Properties: map[string]Schema{"id": {Type: "string"}},
+								}},
+							},
+						},
+						// "default" has no numeric status and must be skipped.
+						"default": {},
+					},
+				},
+			},
+		},
+	}
+
+	src := genSrc(t, doc)
+
+	wantContains := []string{
+		"CreateWidget(w http.ResponseWriter, r *http.Request, body CreateWidgetBody)",
+		"type CreateWidgetBody struct",
+		`json:"name"`,
+		"type CreateWidgetResponse201 struct",
+		"func WriteCreateWidgetResponse201(w http.ResponseWriter, body CreateWidgetResponse201) error",
+		"json.NewDecoder(r.Body).Decode(&rawBody)",
+		`openapi.ValidateRequired("name", false)`,
+		`openapi.ValidateEnum("kind", v, []string{"a", "b"})`,
+		`openapi.ValidateRange("age", n, floatPtr(0), floatPtr(150))`,
+		"json.NewEncoder(w).Encode(body)",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(src, want) {
+			t.Errorf("expected generated source to contain %q, got:\n%s", want, src)
+		}
+	}
+	if strings.Contains(src, "CreateWidgetResponseDefault") {
+		t.Errorf("expected the \"default\" response to be skipped, got:\n%s", src)
+	}
+}
@@ -0,0 +1,42 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Parse reads an OpenAPI 3 document from data. JSON input is detected by a
+// leading '{' and decoded directly; anything else is treated as YAML.
+func Parse(data []byte) (*Document, error) {
+	trimmed := bytes.TrimSpace(data)
+
+	var raw []byte
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		raw = trimmed
+	} else {
+		value, err := decodeYAML(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("openapi: parsing yaml: %w", err)
+		}
+
+		// decodeYAML already produces the map[string]any/[]any shape
+		// encoding/json expects, so round-tripping through JSON lets us
+		// reuse the struct tags on Document et al. instead of maintaining
+		// a second, reflection-based decoder.
+		raw, err = json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("openapi: normalizing yaml: %w", err)
+		}
+	}
+
+	var doc Document
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("openapi: parsing document: %w", err)
+	}
+	if doc.OpenAPI == "" {
+		return nil, fmt.Errorf("openapi: missing required \"openapi\" field")
+	}
+
+	return &doc, nil
+}
@@ -0,0 +1,63 @@
+package openapi
+
+import (
+	"fmt"
+	"time"
+)
+
+// The Validate* helpers implement the small slice of JSON Schema keyword
+// checks generated code needs at request time: required fields, enums,
+// numeric bounds and string formats. They are exported so generated
+// ServerInterface wiring (see Generate) can call them directly.
+
+// ValidateRequired reports an error if a required parameter was not
+// supplied.
+func ValidateRequired(name string, present bool) error {
+	if !present {
+		return fmt.Errorf("%s: required parameter is missing", name)
+	}
+	return nil
+}
+
+// ValidateEnum reports an error if value is not one of enum's members. A
+// nil enum means no restriction.
+func ValidateEnum(name, value string, enum []string) error {
+	if len(enum) == 0 {
+		return nil
+	}
+	for _, e := range enum {
+		if e == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: %q is not one of %v", name, value, enum)
+}
+
+// ValidateRange reports an error if value falls outside [min, max]. Either
+// bound may be nil to leave that side unconstrained.
+func ValidateRange(name string, value float64, min, max *float64) error {
+	if min != nil && value < *min {
+		return fmt.Errorf("%s: %v is below minimum %v", name, value, *min)
+	}
+	if max != nil && value > *max {
+		return fmt.Errorf("%s: %v is above maximum %v", name, value, *max)
+	}
+	return nil
+}
+
+// ValidateFormat reports an error if value does not satisfy the named
+// JSON Schema string format. Unrecognized formats are accepted, matching
+// the permissive stance most OpenAPI validators take.
+func ValidateFormat(name, value, format string) error {
+	switch format {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return fmt.Errorf("%s: %q is not a valid date-time: %w", name, value, err)
+		}
+	case "date":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return fmt.Errorf("%s: %q is not a valid date: %w", name, value, err)
+		}
+	}
+	return nil
+}
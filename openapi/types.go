@@ -0,0 +1,85 @@
+// Package openapi is a small, stdlib-only subset of an OpenAPI 3 document
+// model, together with a code generator (see Generate) that turns a
+// document into a Go ServerInterface and RegisterHandlers function. It is
+// intentionally scoped to what cmd/oapi-gen needs and is not a general
+// purpose OpenAPI implementation.
+//
+// Generate validates path and query parameters (required, enum, numeric
+// range, format) and passes them to ServerInterface methods as strings.
+// A JSON request body is decoded and validated the same way and passed as
+// a typed struct argument; a JSON response with a schema gets a generated
+// struct and a Write<Op>Response<Status> helper. Only the
+// "application/json" content type and flat (non-nested-object) schemas are
+// supported for bodies and responses; see Generate's doc comment for the
+// exact scope.
+package openapi
+
+// Document is the root of an OpenAPI 3 document.
+type Document struct {
+	OpenAPI string              `json:"openapi" yaml:"openapi"`
+	Info    Info                `json:"info" yaml:"info"`
+	Paths   map[string]PathItem `json:"paths" yaml:"paths"`
+}
+
+// Info carries the document's metadata.
+type Info struct {
+	Title   string `json:"title" yaml:"title"`
+	Version string `json:"version" yaml:"version"`
+}
+
+// PathItem groups the operations available on a single path.
+type PathItem struct {
+	Get    *Operation `json:"get" yaml:"get"`
+	Post   *Operation `json:"post" yaml:"post"`
+	Put    *Operation `json:"put" yaml:"put"`
+	Delete *Operation `json:"delete" yaml:"delete"`
+}
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	OperationID string              `json:"operationId" yaml:"operationId"`
+	Parameters  []Parameter         `json:"parameters" yaml:"parameters"`
+	RequestBody *RequestBody        `json:"requestBody" yaml:"requestBody"`
+	Responses   map[string]Response `json:"responses" yaml:"responses"`
+}
+
+// Parameter describes a single path or query parameter.
+type Parameter struct {
+	Name     string `json:"name" yaml:"name"`
+	In       string `json:"in" yaml:"in"` // "path" or "query"
+	Required bool   `json:"required" yaml:"required"`
+	Schema   Schema `json:"schema" yaml:"schema"`
+}
+
+// RequestBody describes the body accepted by an operation.
+type RequestBody struct {
+	Required bool                 `json:"required" yaml:"required"`
+	Content  map[string]MediaType `json:"content" yaml:"content"`
+}
+
+// Response describes a single named response (keyed by status code, or
+// "default").
+type Response struct {
+	Description string               `json:"description" yaml:"description"`
+	Content     map[string]MediaType `json:"content" yaml:"content"`
+}
+
+// MediaType associates a schema with a content type such as
+// "application/json".
+type MediaType struct {
+	Schema Schema `json:"schema" yaml:"schema"`
+}
+
+// Schema is a (heavily simplified) JSON Schema, covering the subset used
+// to describe request/response shapes: scalar types, enums, numeric
+// bounds, string formats and object properties.
+type Schema struct {
+	Type       string            `json:"type" yaml:"type"`
+	Format     string            `json:"format" yaml:"format"`
+	Enum       []string          `json:"enum" yaml:"enum"`
+	Minimum    *float64          `json:"minimum" yaml:"minimum"`
+	Maximum    *float64          `json:"maximum" yaml:"maximum"`
+	Properties map[string]Schema `json:"properties" yaml:"properties"`
+	Required   []string          `json:"required" yaml:"required"`
+	Items      *Schema           `json:"items" yaml:"items"`
+}
@@ -0,0 +1,208 @@
+package openapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// decodeYAML parses a restricted subset of YAML - block mappings, block
+// sequences and scalars, indented with spaces - into the same
+// map[string]any / []any shape encoding/json would produce for an
+// equivalent JSON document. Flow style is limited to single-line flow
+// sequences of scalars (e.g. enum: [a, b]); flow mappings, anchors,
+// multi-document streams and tags are not supported. That is enough for
+// the OpenAPI documents this package needs to read.
+func decodeYAML(data []byte) (any, error) {
+	lines := splitYAMLLines(string(data))
+	if len(lines) == 0 {
+		return map[string]any{}, nil
+	}
+
+	value, _, err := parseYAMLBlock(lines, 0, lines[0].indent)
+	return value, err
+}
+
+type yamlLine struct {
+	indent  int
+	content string
+}
+
+func splitYAMLLines(src string) []yamlLine {
+	var lines []yamlLine
+	for _, raw := range strings.Split(src, "\n") {
+		trimmed := raw
+		if i := strings.Index(trimmed, "#"); i != -1 {
+			trimmed = trimmed[:i]
+		}
+		trimmed = strings.TrimRight(trimmed, " \t\r")
+		if strings.TrimSpace(trimmed) == "" {
+			continue
+		}
+
+		indent := 0
+		for indent < len(trimmed) && trimmed[indent] == ' ' {
+			indent++
+		}
+		lines = append(lines, yamlLine{indent: indent, content: trimmed[indent:]})
+	}
+	return lines
+}
+
+// parseYAMLBlock parses the block starting at lines[start] that shares
+// indent level "indent", returning the decoded value and the index of the
+// next unconsumed line.
+func parseYAMLBlock(lines []yamlLine, start, indent int) (any, int, error) {
+	if start >= len(lines) || lines[start].indent != indent {
+		return nil, start, fmt.Errorf("openapi: malformed yaml at line %d", start+1)
+	}
+
+	if strings.HasPrefix(lines[start].content, "- ") || lines[start].content == "-" {
+		return parseYAMLSequence(lines, start, indent)
+	}
+	return parseYAMLMapping(lines, start, indent)
+}
+
+func parseYAMLSequence(lines []yamlLine, start, indent int) (any, int, error) {
+	var seq []any
+	i := start
+
+	for i < len(lines) && lines[i].indent == indent && (lines[i].content == "-" || strings.HasPrefix(lines[i].content, "- ")) {
+		rest := strings.TrimPrefix(strings.TrimPrefix(lines[i].content, "-"), " ")
+
+		if rest == "" {
+			// nested block on following, more-indented lines
+			if i+1 < len(lines) && lines[i+1].indent > indent {
+				value, next, err := parseYAMLBlock(lines, i+1, lines[i+1].indent)
+				if err != nil {
+					return nil, i, err
+				}
+				seq = append(seq, value)
+				i = next
+				continue
+			}
+			seq = append(seq, nil)
+			i++
+			continue
+		}
+
+		if key, value, isMapKey := splitYAMLMappingLine(rest); isMapKey {
+			// "- key: value" starts an inline mapping entry; fold it and any
+			// following, more-indented sibling keys into one mapping.
+			itemIndent := indent + 2
+			synthetic := []yamlLine{{indent: itemIndent, content: fmt.Sprintf("%s: %s", key, value)}}
+			j := i + 1
+			for j < len(lines) && lines[j].indent >= itemIndent {
+				synthetic = append(synthetic, yamlLine{indent: lines[j].indent, content: lines[j].content})
+				j++
+			}
+			mapping, _, err := parseYAMLMapping(synthetic, 0, itemIndent)
+			if err != nil {
+				return nil, i, err
+			}
+			seq = append(seq, mapping)
+			i = j
+			continue
+		}
+
+		seq = append(seq, parseYAMLScalar(rest))
+		i++
+	}
+
+	return seq, i, nil
+}
+
+func parseYAMLMapping(lines []yamlLine, start, indent int) (any, int, error) {
+	mapping := map[string]any{}
+	i := start
+
+	for i < len(lines) && lines[i].indent == indent {
+		key, value, ok := splitYAMLMappingLine(lines[i].content)
+		if !ok {
+			return nil, i, fmt.Errorf("openapi: expected mapping entry at line %d", i+1)
+		}
+
+		if value != "" {
+			mapping[key] = parseYAMLScalar(value)
+			i++
+			continue
+		}
+
+		if i+1 < len(lines) && lines[i+1].indent > indent {
+			nested, next, err := parseYAMLBlock(lines, i+1, lines[i+1].indent)
+			if err != nil {
+				return nil, i, err
+			}
+			mapping[key] = nested
+			i = next
+			continue
+		}
+
+		mapping[key] = nil
+		i++
+	}
+
+	return mapping, i, nil
+}
+
+// splitYAMLMappingLine splits "key: value" (value may be empty) and
+// reports whether content looks like a mapping entry at all. The key is
+// dequoted the same way scalar values are, so quoted keys like "200" map
+// to the unquoted string "200" rather than the literal `"200"`.
+func splitYAMLMappingLine(content string) (key, value string, ok bool) {
+	k, v, found := strings.Cut(content, ":")
+	if !found {
+		return "", "", false
+	}
+	key = dequote(strings.TrimSpace(k))
+	value = strings.TrimSpace(v)
+	if key == "" {
+		return "", "", false
+	}
+	return key, value, true
+}
+
+// dequote strips a single layer of matching double or single quotes from
+// s, if present.
+func dequote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' && s[len(s)-1] == '"' || s[0] == '\'' && s[len(s)-1] == '\'') {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func parseYAMLScalar(s string) any {
+	if len(s) >= 2 && (s[0] == '"' && s[len(s)-1] == '"' || s[0] == '\'' && s[len(s)-1] == '\'') {
+		return dequote(s)
+	}
+
+	// minimal flow sequence support, e.g. enum: [a, "b", 3] - enough for
+	// the inline lists OpenAPI documents commonly use for enums.
+	if len(s) >= 2 && s[0] == '[' && s[len(s)-1] == ']' {
+		inner := strings.TrimSpace(s[1 : len(s)-1])
+		if inner == "" {
+			return []any{}
+		}
+		items := strings.Split(inner, ",")
+		seq := make([]any, 0, len(items))
+		for _, item := range items {
+			seq = append(seq, parseYAMLScalar(strings.TrimSpace(item)))
+		}
+		return seq
+	}
+
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null", "~", "":
+		return nil
+	}
+
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return n
+	}
+
+	return s
+}
@@ -0,0 +1,30 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeYAMLQuotedMappingKeys(t *testing.T) {
+	src := `
+responses:
+  "200":
+    description: OK
+  '404':
+    description: Not Found
+`
+	got, err := decodeYAML([]byte(src))
+	if err != nil {
+		t.Fatalf("decodeYAML() error = %v", err)
+	}
+
+	want := map[string]any{
+		"responses": map[string]any{
+			"200": map[string]any{"description": "OK"},
+			"404": map[string]any{"description": "Not Found"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("decodeYAML() = %#v, want %#v", got, want)
+	}
+}
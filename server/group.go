@@ -0,0 +1,138 @@
+// Package server supervises a set of http.Server values as a single unit:
+// starting them together, shutting them down together on SIGINT/SIGTERM,
+// and reporting every failure encountered along the way.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Group runs a fixed set of http.Server values and coordinates their
+// shutdown.
+type Group struct {
+	servers      []*http.Server
+	drainTimeout time.Duration
+	ready        atomic.Bool
+}
+
+// NewGroup returns a Group for servers. drainTimeout bounds how long Run
+// waits for in-flight requests to finish once shutdown begins. Further
+// servers can be registered with Add before Run is called.
+func NewGroup(drainTimeout time.Duration, servers ...*http.Server) *Group {
+	return &Group{servers: servers, drainTimeout: drainTimeout}
+}
+
+// Add registers additional servers with the group. It must be called
+// before Run.
+func (g *Group) Add(servers ...*http.Server) {
+	g.servers = append(g.servers, servers...)
+}
+
+// Run starts every server in the group and blocks until ctx is cancelled,
+// SIGINT/SIGTERM is received, or any server stops serving, at which point
+// it shuts every server down
+// with the group's drain timeout. It returns the aggregate of every
+// listen, serve and Shutdown error encountered, via errors.Join, or nil if
+// everything exited cleanly.
+func (g *Group) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listeners := make([]net.Listener, len(g.servers))
+	for i, s := range g.servers {
+		ln, err := net.Listen("tcp", s.Addr)
+		if err != nil {
+			for _, opened := range listeners[:i] {
+				opened.Close()
+			}
+			return fmt.Errorf("server: listening on %s: %w", s.Addr, err)
+		}
+		listeners[i] = ln
+	}
+	g.ready.Store(true)
+
+	errCh := make(chan error, len(g.servers))
+	for i, s := range g.servers {
+		go func(s *http.Server, ln net.Listener) {
+			err := s.Serve(ln)
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errCh <- err
+				return
+			}
+			errCh <- nil
+		}(s, listeners[i])
+	}
+
+	var early error
+	receivedEarly := false
+	select {
+	case <-ctx.Done():
+	case early = <-errCh:
+		receivedEarly = true
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), g.drainTimeout)
+	defer cancel()
+
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	if early != nil {
+		errs = append(errs, early)
+	}
+
+	// Shut every server down concurrently against the shared deadline, so
+	// one slow-draining server can't starve the others of their share of
+	// drainTimeout.
+	var wg sync.WaitGroup
+	wg.Add(len(g.servers))
+	for _, s := range g.servers {
+		go func(s *http.Server) {
+			defer wg.Done()
+			if err := s.Shutdown(shutdownCtx); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(s)
+	}
+	wg.Wait()
+
+	remaining := len(g.servers)
+	if receivedEarly {
+		remaining--
+	}
+	for i := 0; i < remaining; i++ {
+		if err := <-errCh; err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Livez reports whether the process is alive; it always responds 200 OK
+// once the handler is reachable.
+func (g *Group) Livez(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// Readyz reports whether every server in the group has started listening.
+func (g *Group) Readyz(w http.ResponseWriter, r *http.Request) {
+	if !g.ready.Load() {
+		http.Error(w, "not ready", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
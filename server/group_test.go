@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// freeAddr reserves an ephemeral port, releases it, and returns its
+// address for a test server to bind to. There is a small TOCTOU window,
+// but it's the standard way to get a predictable address for an
+// http.Server without plumbing the listener itself out of Run.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("reserving port: %v", err)
+	}
+	addr := ln.Addr().String()
+	if err := ln.Close(); err != nil {
+		t.Fatalf("releasing port: %v", err)
+	}
+	return addr
+}
+
+func waitReady(t *testing.T, g *Group) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if g.ready.Load() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("group never became ready")
+}
+
+// TestGroupShutdownIsConcurrent exercises the bug where a slow-draining
+// server starved a later server of its share of the shared drain
+// deadline: two servers each hold one slow in-flight request, and Run
+// must drain them in parallel rather than one after another.
+func TestGroupShutdownIsConcurrent(t *testing.T) {
+	const drainTimeout = 400 * time.Millisecond
+	const requestDelay = 200 * time.Millisecond
+
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(requestDelay)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	addrA, addrB := freeAddr(t), freeAddr(t)
+	serverA := &http.Server{Addr: addrA, Handler: slow}
+	serverB := &http.Server{Addr: addrB, Handler: slow}
+
+	group := NewGroup(drainTimeout, serverA, serverB)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErrCh := make(chan error, 1)
+	go func() { runErrCh <- group.Run(ctx) }()
+
+	waitReady(t, group)
+
+	var wg sync.WaitGroup
+	for _, addr := range []string{addrA, addrB} {
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+			resp, err := http.Get("http://" + addr + "/")
+			if err == nil {
+				resp.Body.Close()
+			}
+		}(addr)
+	}
+
+	// give both requests a moment to land on their handlers before
+	// triggering shutdown.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	start := time.Now()
+	if err := <-runErrCh; err != nil {
+		t.Fatalf("Run() error = %v, want nil (both servers should drain within the shared deadline)", err)
+	}
+	elapsed := time.Since(start)
+	wg.Wait()
+
+	// Sequential shutdown would need roughly 2*requestDelay; concurrent
+	// shutdown needs roughly 1*requestDelay plus scheduling slop.
+	if elapsed > requestDelay+150*time.Millisecond {
+		t.Fatalf("shutdown took %v, want close to %v (servers should drain concurrently, not sequentially)", elapsed, requestDelay)
+	}
+}
+
+// TestGroupRunClosesListenersOnPartialFailure exercises the fd leak where
+// a later server's failed net.Listen left earlier servers' listeners
+// open forever.
+func TestGroupRunClosesListenersOnPartialFailure(t *testing.T) {
+	addrA := freeAddr(t)
+
+	occupiedLn, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("reserving occupied address: %v", err)
+	}
+	defer occupiedLn.Close()
+	addrB := occupiedLn.Addr().String()
+
+	serverA := &http.Server{Addr: addrA}
+	serverB := &http.Server{Addr: addrB}
+	group := NewGroup(time.Second, serverA, serverB)
+
+	if err := group.Run(context.Background()); err == nil {
+		t.Fatal("Run() error = nil, want a listen error for the already-occupied address")
+	}
+
+	// The listener opened for serverA before serverB's listen failed must
+	// have been closed, otherwise rebinding addrA here fails.
+	ln, err := net.Listen("tcp", addrA)
+	if err != nil {
+		t.Fatalf("rebinding %s after Run() failed: %v (listener for an earlier server was leaked)", addrA, err)
+	}
+	ln.Close()
+}
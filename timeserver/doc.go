@@ -0,0 +1,5 @@
+// Package timeserver defines the time-server example's ServerInterface and
+// routing, generated from ../api/timeserver.yaml.
+package timeserver
+
+//go:generate go run ../cmd/oapi-gen -spec ../api/timeserver.yaml -package timeserver -out timeserver_gen.go